@@ -0,0 +1,108 @@
+package pop3
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"strings"
+
+	"github.com/tempestsecurity/go-pop3/sasl"
+)
+
+// Auth authenticates with the server using the SASL mechanism mech, as
+// described in RFC 5034. It writes the AUTH command, with an initial
+// response appended when the mechanism provides one, then drives the
+// challenge/response exchange: each continuation line is base64-decoded,
+// handed to mech.Next, and the base64-encoded reply written back, until
+// the server replies +OK or -ERR.
+//
+// The end of the exchange is detected from the line's own "+OK"/"-ERR"
+// marker, not by trying to base64-decode it: an empty continuation
+// payload decodes successfully too (a bare "+OK" strips down to ""), so
+// decodability alone can't tell a real continuation from a finished
+// exchange.
+func (c *Client) Auth(mech sasl.Client) error {
+	defer c.applyDeadline()()
+
+	name, ir, err := mech.Start()
+	if err != nil {
+		return err
+	}
+
+	if ir != nil {
+		c.logger.Debug("C:", "line", "AUTH "+name, "redacted", true)
+		err = c.Text.WriteLine("AUTH %s %s", name, base64.StdEncoding.EncodeToString(ir))
+	} else {
+		c.logCmd("AUTH %s", name)
+		err = c.Text.WriteLine("AUTH %s", name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		line, err := c.Text.ReadLine()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+OK"):
+			c.logResp(line, nil)
+			return nil
+
+		case strings.HasPrefix(line, "-ERR"):
+			respErr := ResponseError(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+			c.logResp("", respErr)
+			return respErr
+
+		case strings.HasPrefix(line, "+ "):
+			c.logResp(line, nil)
+
+			challenge, err := base64.StdEncoding.DecodeString(line[len("+ "):])
+			if err != nil {
+				return err
+			}
+
+			resp, err := mech.Next(challenge)
+			if err != nil {
+				return err
+			}
+
+			c.logAuthCmd()
+
+			if err = c.Text.WriteLine("%s", base64.StdEncoding.EncodeToString(resp)); err != nil {
+				return err
+			}
+
+		default:
+			return ResponseError("unexpected AUTH response: " + line)
+		}
+	}
+}
+
+// APOP authenticates using the APOP command (RFC 1939 §7), hashing the
+// <msg-id> timestamp token from the server's greeting banner together
+// with the shared secret so the password is never sent in the clear.
+func (c *Client) APOP(user, secret string) error {
+	digest := md5.Sum([]byte(apopTimestamp(c.Banner) + secret))
+	return c.cmdSimple("APOP %s %x", user, digest)
+}
+
+// apopTimestamp extracts the "<...>" msg-id substring RFC 1939 §7 says
+// the APOP digest is keyed on, e.g. "<1896.697170952@dbc.mtview.ca.us>"
+// out of a banner like "POP3 server ready <1896.697170952@dbc.mtview.ca.us>".
+// If banner has no bracketed token, it is returned unchanged.
+func apopTimestamp(banner string) string {
+	start := strings.IndexByte(banner, '<')
+	if start < 0 {
+		return banner
+	}
+
+	end := strings.IndexByte(banner[start:], '>')
+	if end < 0 {
+		return banner
+	}
+
+	return banner[start : start+end+1]
+}
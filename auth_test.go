@@ -0,0 +1,55 @@
+package pop3
+
+import (
+	"crypto/md5"
+	"fmt"
+	"testing"
+)
+
+func TestApopTimestamp(t *testing.T) {
+	cases := []struct {
+		name   string
+		banner string
+		want   string
+	}{
+		{
+			name:   "bare timestamp",
+			banner: "<1896.697170952@dbc.mtview.ca.us>",
+			want:   "<1896.697170952@dbc.mtview.ca.us>",
+		},
+		{
+			name:   "leading descriptive text",
+			banner: "POP3 server ready <1896.697170952@dbc.mtview.ca.us>",
+			want:   "<1896.697170952@dbc.mtview.ca.us>",
+		},
+		{
+			name:   "no msg-id present",
+			banner: "POP3 server ready",
+			want:   "POP3 server ready",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := apopTimestamp(tc.banner); got != tc.want {
+				t.Errorf("apopTimestamp(%q) = %q, want %q", tc.banner, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestAPOPDigest asserts against the worked example in RFC 1939 §7,
+// including the leading descriptive text real servers send before the
+// msg-id, to guard against hashing the whole banner instead of just the
+// bracketed timestamp.
+func TestAPOPDigest(t *testing.T) {
+	const banner = "POP3 server ready <1896.697170952@dbc.mtview.ca.us>"
+	const secret = "tanstaaf"
+	const want = "c4c9334bac560ecc979e58001b3e22fb"
+
+	digest := md5.Sum([]byte(apopTimestamp(banner) + secret))
+
+	if got := fmt.Sprintf("%x", digest); got != want {
+		t.Errorf("APOP digest = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,67 @@
+package pop3
+
+import (
+	"io/ioutil"
+	"strconv"
+)
+
+// Capa issues a CAPA command to the server (RFC 2449) and returns its
+// advertised capabilities, e.g. "TOP", "USER", "SASL PLAIN LOGIN", "STLS",
+// "PIPELINING", "EXPIRE", "UIDL", "RESP-CODES".
+func (c *Client) Capa() ([]string, error) {
+	var caps []string
+
+	err := c.cmdReadLines("CAPA", func(line string) error {
+		caps = append(caps, line)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return caps, nil
+}
+
+// listMessages returns the array of MessageInfo to use for retrieving
+// mail: UidlAll, falling back to ListAll (with the message number
+// substituted for the unavailable unique id) only when UidlAll itself
+// fails, e.g. because the server doesn't implement UIDL. CAPA isn't
+// consulted here: plenty of older servers implement UIDL without
+// implementing CAPA, and their real, stable UIDs are worth trying for
+// before giving up on them.
+func (c *Client) listMessages() ([]MessageInfo, error) {
+	list, err := c.UidlAll()
+	if err == nil {
+		return list, nil
+	}
+
+	list, err = c.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list {
+		list[i].Uid = strconv.Itoa(list[i].Number)
+	}
+
+	return list, nil
+}
+
+// Top issues a TOP command to the server (RFC 1939 §5) using the
+// provided mail number and number of body lines, and returns the message
+// headers plus the first lines lines of the body.
+func (c *Client) Top(number, lines int) (string, error) {
+	r, err := c.TopReader(number, lines)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
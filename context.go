@@ -0,0 +1,147 @@
+package pop3
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+)
+
+// WithContext installs ctx as the deadline/cancellation source for the
+// next command issued on c, and returns c so calls can be chained, e.g.
+// c.WithContext(ctx).Retr(1). The context's deadline, if any, is applied
+// to the underlying connection before the command's write/read and
+// cleared once the command returns; if ctx is cancelled while the
+// command is in flight, the connection is closed to unblock it.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c.ctx = ctx
+	c.ctxCancel = nil
+	return c
+}
+
+// SetTimeout installs a context with a d timeout for the next command,
+// as a shorthand for WithContext(context.WithTimeout(...)).
+func (c *Client) SetTimeout(d time.Duration) *Client {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	c.ctx = ctx
+	c.ctxCancel = cancel
+	return c
+}
+
+// watchDone spawns a goroutine that closes conn if ctx is done before the
+// returned stop func is called, so a blocked read/write on conn can't
+// hang past ctx's cancellation even when ctx carries no deadline (e.g.
+// context.WithCancel). The caller must always call stop, whether or not
+// ctx ever fired, to let the goroutine exit.
+func watchDone(ctx context.Context, conn net.Conn) (stop func()) {
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// applyDeadline consumes the context installed by WithContext/SetTimeout,
+// if any, applies its deadline to the connection, and arranges for the
+// connection to be closed if the context is done before the returned
+// cancel func runs. Most commands should defer c.applyDeadline()() as
+// their first statement so the cancel fires when the command returns;
+// RetrReader/TopReader instead hold the returned cancel open until their
+// reader is Closed, since the deadline needs to cover the streamed body
+// too.
+func (c *Client) applyDeadline() (cancel func()) {
+	ctx := c.ctx
+	ctxCancel := c.ctxCancel
+	c.ctx = nil
+	c.ctxCancel = nil
+
+	if ctx == nil {
+		return func() {}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetDeadline(deadline)
+	}
+
+	stopWatch := watchDone(ctx, c.conn)
+
+	return func() {
+		stopWatch()
+		c.conn.SetDeadline(time.Time{})
+
+		if ctxCancel != nil {
+			ctxCancel()
+		}
+	}
+}
+
+// DialContext returns a new Client connected to a POP server at addr,
+// using ctx to bound both the dial and the initial greeting read — a
+// bare cancellation with no deadline still unblocks a stalled greeting
+// read, since the connection is closed as soon as ctx is done.
+// The addr must include a port number.
+func DialContext(ctx context.Context, addr string) (*Client, error) {
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	stopWatch := watchDone(ctx, conn)
+	c, err := NewClient(conn)
+	stopWatch()
+
+	conn.SetDeadline(time.Time{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// DialTLSContext returns a new TLS Client connected to a POP server at
+// addr, using ctx to bound both the dial/handshake and the initial
+// greeting read — a bare cancellation with no deadline still unblocks a
+// stalled greeting read, since the connection is closed as soon as ctx
+// is done. The addr must be host:port.
+func DialTLSContext(ctx context.Context, addr, cert string, secure bool) (*Client, error) {
+	config, err := tlsConfig(cert, secure)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &tls.Dialer{Config: config}
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	stopWatch := watchDone(ctx, conn)
+	c, err := NewClient(conn)
+	stopWatch()
+
+	conn.SetDeadline(time.Time{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,59 @@
+package pop3
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// TestWatchDoneClosesOnCancel exercises the bare-cancellation case (no
+// deadline) that DialContext/DialTLSContext and applyDeadline rely on to
+// unblock a stalled read: closing conn as soon as ctx is done.
+func TestWatchDoneClosesOnCancel(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := watchDone(ctx, client)
+	defer stop()
+
+	readErr := make(chan error, 1)
+
+	go func() {
+		buf := make([]byte, 1)
+		_, err := client.Read(buf)
+		readErr <- err
+	}()
+
+	cancel()
+
+	select {
+	case err := <-readErr:
+		if err == nil {
+			t.Fatal("expected Read to fail once ctx was cancelled, got nil error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Read did not unblock after ctx was cancelled")
+	}
+}
+
+// TestWatchDoneStopLeavesConnOpen confirms that calling stop without
+// ever cancelling ctx leaves conn open.
+func TestWatchDoneStopLeavesConnOpen(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	go server.Read(make([]byte, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stop := watchDone(ctx, client)
+	stop()
+
+	if _, err := client.Write([]byte{0}); err != nil {
+		t.Fatalf("connection was unexpectedly closed after stop: %v", err)
+	}
+}
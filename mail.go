@@ -0,0 +1,154 @@
+package pop3
+
+import (
+	"io"
+	"net/mail"
+)
+
+// ReceiveParsedMailFunc is the type of the function called for each mail
+// by ReceiveParsedMail and ReceiveParsedMailTls. Its arguments are the
+// mail's number, uid, parsed message, and mail receiving error.
+// if this function returns true value, the mail will be deleted,
+// if its returns EOF, skip the all mail of remaining.
+// (after deleting mail, if necessary)
+type ReceiveParsedMailFunc func(number int, uid string, msg *mail.Message, err error) (bool, error)
+
+// ReceiveParsedMail connects to the server at addr, and authenticates
+// with user and pass, and calling receiveFn with each mail parsed into a
+// *mail.Message via net/mail.ReadMessage, streamed through RetrReader
+// rather than buffered whole. msg.Header gives access to From, Subject,
+// and Date (e.g. via mail.ParseAddressList) and MIME Content-Type
+// params, and msg.Body is an io.Reader for MIME walking.
+func ReceiveParsedMail(addr, user, pass string, receiveFn ReceiveParsedMailFunc) error {
+	c, err := Auth(addr, user, pass)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil && err != EOF {
+			c.Rset()
+		}
+
+		c.Quit()
+		c.Close()
+	}()
+
+	var mis []MessageInfo
+
+	if mis, err = c.listMessages(); err != nil {
+		return err
+	}
+
+	for _, mi := range mis {
+		var msg *mail.Message
+		var body io.Closer
+
+		msg, body, err = c.retrParsed(mi.Number)
+
+		del, err := receiveFn(mi.Number, mi.Uid, msg, err)
+
+		if body != nil {
+			body.Close()
+		}
+
+		if c.IsClosed() {
+			c.logger.Info("reconnecting", "addr", addr)
+			c, err = Auth(addr, user, pass)
+		}
+
+		if err != nil && err != EOF {
+			return err
+		}
+
+		if del {
+			if err = c.Dele(mi.Number); err != nil {
+				return err
+			}
+		}
+
+		if err == EOF {
+			break
+		}
+	}
+
+	return nil
+}
+
+// ReceiveParsedMailTls connects to the TLS server at addr, and
+// authenticates with user and pass, and calling receiveFn with each mail
+// parsed into a *mail.Message. See ReceiveParsedMail.
+func ReceiveParsedMailTls(addr, user, pass, cert string, receiveFn ReceiveParsedMailFunc) error {
+	c, err := AuthTls(addr, user, pass, cert)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil && err != EOF {
+			c.Rset()
+		}
+
+		c.Quit()
+		c.Close()
+	}()
+
+	var mis []MessageInfo
+
+	if mis, err = c.listMessages(); err != nil {
+		return err
+	}
+
+	for _, mi := range mis {
+		var msg *mail.Message
+		var body io.Closer
+
+		msg, body, err = c.retrParsed(mi.Number)
+
+		del, err := receiveFn(mi.Number, mi.Uid, msg, err)
+
+		if body != nil {
+			body.Close()
+		}
+
+		if c.IsClosed() {
+			c.logger.Info("reconnecting", "addr", addr)
+			c, err = AuthTls(addr, user, pass, cert)
+		}
+
+		if err != nil && err != EOF {
+			return err
+		}
+
+		if del {
+			if err = c.Dele(mi.Number); err != nil {
+				return err
+			}
+		}
+
+		if err == EOF {
+			break
+		}
+	}
+
+	return nil
+}
+
+// retrParsed retrieves mail number and parses its headers into a
+// *mail.Message, streaming through RetrReader rather than buffering the
+// raw message first. msg.Body reads lazily from the returned io.Closer,
+// which the caller must Close once it is done reading msg.Body.
+func (c *Client) retrParsed(number int) (*mail.Message, io.Closer, error) {
+	r, err := c.RetrReader(number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		r.Close()
+		return nil, nil, err
+	}
+
+	return msg, r, nil
+}
@@ -2,6 +2,7 @@
 package pop3
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -33,9 +34,18 @@ type MessageInfo struct {
 type Client struct {
 	// Text is the pop3.Conn used by the Client.
 	Text *Conn
+	// Banner is the server's greeting line, captured by NewClient. It is
+	// used as the timestamp for APOP.
+	Banner string
 	// keep a reference to the connection so it can be used to create a TLS
 	// connection later
 	conn net.Conn
+	// ctx and ctxCancel are installed by WithContext/SetTimeout and
+	// consumed by applyDeadline on the next command.
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+	// logger receives the wire conversation; see SetLogger.
+	logger Logger
 }
 
 // Dial returns a new Client connected to an POP server at addr.
@@ -53,38 +63,46 @@ func Dial(addr string) (*Client, error) {
 // Dial returns a new TLS Client connected to an POP server at addr.
 // The addr must be host:port.
 func DialTls(addr, cert string, secure bool) (*Client, error) {
-	var err error
-	var conn *tls.Conn
+	config, err := tlsConfig(cert, secure)
+	if err != nil {
+		return nil, err
+	}
 
-	if secure {
-		pem, err := ioutil.ReadFile(cert)
-		if err != nil {
-			return nil, err
-		}
+	conn, err := tls.Dial("tcp", addr, config)
+	if err != nil {
+		return nil, err
+	}
 
-		roots := x509.NewCertPool()
-		ok := roots.AppendCertsFromPEM(pem)
-		if !ok {
-			return nil, fmt.Errorf("Failed to parse root certificate")
-		}
+	return NewClient(conn)
+}
 
-		conn, err = tls.Dial("tcp", addr, &tls.Config{RootCAs: roots})
-	} else {
-		conn, err = tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true})
+// tlsConfig builds the *tls.Config used by DialTls/DialTLSContext: when
+// secure is true it trusts only the root certificate found at cert,
+// otherwise it skips verification entirely.
+func tlsConfig(cert string, secure bool) (*tls.Config, error) {
+	if !secure {
+		return &tls.Config{InsecureSkipVerify: true}, nil
 	}
 
+	pem, err := ioutil.ReadFile(cert)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewClient(conn)
+	roots := x509.NewCertPool()
+	ok := roots.AppendCertsFromPEM(pem)
+	if !ok {
+		return nil, fmt.Errorf("Failed to parse root certificate")
+	}
+
+	return &tls.Config{RootCAs: roots}, nil
 }
 
 // NewClient returns a new Client using an existing connection.
 func NewClient(conn net.Conn) (*Client, error) {
 	text := NewConn(conn)
 
-	_, err := text.ReadResponse()
+	banner, err := text.ReadResponse()
 
 	if err != nil {
 		if err.Error() == "Cannot read the line." {
@@ -94,7 +112,7 @@ func NewClient(conn net.Conn) (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{Text: text, conn: conn}, nil
+	return &Client{Text: text, conn: conn, Banner: banner, logger: NopLogger{}}, nil
 }
 
 // IsClosed verifies that the connection is closed with the server
@@ -122,21 +140,18 @@ func (c *Client) Stat() (int, uint64, error) {
 // Retr issues a RETR command to the server using the provided mail number
 // and returns mail data.
 func (c *Client) Retr(number int) (string, error) {
-	var err error
-
-	err = c.Text.WriteLine("RETR %d", number)
-
+	r, err := c.RetrReader(number)
 	if err != nil {
 		return "", err
 	}
+	defer r.Close()
 
-	_, err = c.Text.ReadResponse()
-
+	data, err := ioutil.ReadAll(r)
 	if err != nil {
 		return "", err
 	}
 
-	return c.Text.ReadToPeriod()
+	return string(data), nil
 }
 
 // List issues a LIST command to the server using the provided mail number
@@ -172,8 +187,12 @@ func (c *Client) ListAll() ([]MessageInfo, error) {
 // Uidl issues a UIDL command to the server using the provided mail number
 // and returns mail number and unique id.
 func (c *Client) Uidl(number int) (int, string, error) {
+	defer c.applyDeadline()()
+
 	var err error
 
+	c.logCmd("UIDL %d", number)
+
 	err = c.Text.WriteLine("UIDL %d", number)
 
 	if err != nil {
@@ -183,6 +202,7 @@ func (c *Client) Uidl(number int) (int, string, error) {
 	var msg string
 
 	msg, err = c.Text.ReadResponse()
+	c.logResp(msg, err)
 
 	if err != nil {
 		return 0, "", err
@@ -283,7 +303,7 @@ func ReceiveMail(addr, user, pass string, receiveFn ReceiveMailFunc) error {
 
 	var mis []MessageInfo
 
-	if mis, err = c.UidlAll(); err != nil {
+	if mis, err = c.listMessages(); err != nil {
 		return err
 	}
 
@@ -295,6 +315,7 @@ func ReceiveMail(addr, user, pass string, receiveFn ReceiveMailFunc) error {
 		del, err := receiveFn(mi.Number, mi.Uid, data, err)
 
 		if c.IsClosed() {
+			c.logger.Info("reconnecting", "addr", addr)
 			c, err = Auth(addr, user, pass)
 		}
 
@@ -355,7 +376,7 @@ func ReceiveMailTls(addr, user, pass, cert string, receiveFn ReceiveMailFunc) er
 
 	var mis []MessageInfo
 
-	if mis, err = c.UidlAll(); err != nil {
+	if mis, err = c.listMessages(); err != nil {
 		return err
 	}
 
@@ -367,6 +388,7 @@ func ReceiveMailTls(addr, user, pass, cert string, receiveFn ReceiveMailFunc) er
 		del, err := receiveFn(mi.Number, mi.Uid, data, err)
 
 		if c.IsClosed() {
+			c.logger.Info("reconnecting", "addr", addr)
 			c, err = AuthTls(addr, user, pass, cert)
 		}
 
@@ -396,15 +418,20 @@ func ReceiveMailTls(addr, user, pass, cert string, receiveFn ReceiveMailFunc) er
 type ReceiveMailFunc func(number int, uid, data string, err error) (bool, error)
 
 func (c *Client) cmdSimple(format string, args ...interface{}) error {
+	defer c.applyDeadline()()
+
 	var err error
 
+	c.logCmd(format, args...)
+
 	err = c.Text.WriteLine(format, args...)
 
 	if err != nil {
 		return err
 	}
 
-	_, err = c.Text.ReadResponse()
+	msg, err := c.Text.ReadResponse()
+	c.logResp(msg, err)
 
 	if err != nil {
 		return err
@@ -414,8 +441,12 @@ func (c *Client) cmdSimple(format string, args ...interface{}) error {
 }
 
 func (c *Client) cmdStatOrList(name, format string, args ...interface{}) (int, uint64, error) {
+	defer c.applyDeadline()()
+
 	var err error
 
+	c.logCmd(format, args...)
+
 	err = c.Text.WriteLine(format, args...)
 
 	if err != nil {
@@ -425,6 +456,7 @@ func (c *Client) cmdStatOrList(name, format string, args ...interface{}) (int, u
 	var msg string
 
 	msg, err = c.Text.ReadResponse()
+	c.logResp(msg, err)
 
 	if err != nil {
 		return 0, 0, err
@@ -449,15 +481,20 @@ func (c *Client) cmdStatOrList(name, format string, args ...interface{}) (int, u
 }
 
 func (c *Client) cmdReadLines(cmnd string, lineFn lineFunc) error {
+	defer c.applyDeadline()()
+
 	var err error
 
+	c.logCmd(cmnd)
+
 	err = c.Text.WriteLine(cmnd)
 
 	if err != nil {
 		return err
 	}
 
-	_, err = c.Text.ReadResponse()
+	msg, err := c.Text.ReadResponse()
+	c.logResp(msg, err)
 
 	if err != nil {
 		return err
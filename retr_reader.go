@@ -0,0 +1,151 @@
+package pop3
+
+import (
+	"io"
+	"strings"
+)
+
+// dotReader streams a POP3 multi-line response (RFC 1939 §3), undoing
+// dot-stuffing line by line and stopping at the terminating "." line. It
+// implements io.ReadCloser; Close drains any unread lines of the
+// response so the connection stays valid for the next command, whether
+// or not the caller read all the way to io.EOF first.
+//
+// cancel is the teardown for the deadline/cancellation watcher applied
+// by RetrReader/TopReader; it is kept alive for the reader's whole
+// lifetime (not just the initial command) and released exactly once, by
+// Close.
+type dotReader struct {
+	c      *Client
+	buf    []byte
+	done   bool
+	closed bool
+	cancel func()
+}
+
+func newDotReader(c *Client, cancel func()) *dotReader {
+	return &dotReader{c: c, cancel: cancel}
+}
+
+func (d *dotReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+
+		line, err := d.c.Text.ReadLine()
+		if err != nil {
+			d.done = true
+			return 0, err
+		}
+
+		body, terminator := unstuffLine(line)
+		if terminator {
+			d.done = true
+			return 0, io.EOF
+		}
+
+		d.buf = []byte(body + "\r\n")
+	}
+
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+
+	return n, nil
+}
+
+func (d *dotReader) Close() error {
+	defer func() {
+		if !d.closed {
+			d.closed = true
+			d.cancel()
+		}
+	}()
+
+	for !d.done {
+		line, err := d.c.Text.ReadLine()
+		if err != nil {
+			d.done = true
+			return err
+		}
+
+		if _, terminator := unstuffLine(line); terminator {
+			d.done = true
+		}
+	}
+
+	return nil
+}
+
+// unstuffLine undoes RFC 1939 §3 byte-stuffing on a single line of a
+// POP3 multi-line response: a line consisting of exactly "." is the
+// terminator (not body content), while any other line starting with "."
+// has that single leading dot stripped, since it was added only to
+// escape a genuine leading dot in the original body line.
+func unstuffLine(line string) (unstuffed string, terminator bool) {
+	if line == "." {
+		return "", true
+	}
+
+	if strings.HasPrefix(line, ".") {
+		return line[1:], false
+	}
+
+	return line, false
+}
+
+// RetrReader issues a RETR command to the server using the provided mail
+// number and returns a reader over the message, performing POP3
+// dot-unstuffing on the fly so the whole message never has to be
+// buffered in memory. The returned reader must be Closed, even after
+// being read to io.EOF, so a subsequent command can be issued safely.
+//
+// A deadline/cancellation installed with WithContext/SetTimeout is kept
+// in force for as long as the returned reader is open, not just for the
+// initial command, so a server that stalls mid-transfer can't hang a
+// streaming read forever.
+func (c *Client) RetrReader(number int) (io.ReadCloser, error) {
+	cancel := c.applyDeadline()
+
+	c.logCmd("RETR %d", number)
+
+	if err := c.Text.WriteLine("RETR %d", number); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	msg, err := c.Text.ReadResponse()
+	c.logResp(msg, err)
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return newDotReader(c, cancel), nil
+}
+
+// TopReader issues a TOP command to the server using the provided mail
+// number and number of body lines, and returns a reader over the message
+// headers plus the first lines lines of the body. See RetrReader for the
+// reader's dot-unstuffing, Close, and deadline semantics.
+func (c *Client) TopReader(number, lines int) (io.ReadCloser, error) {
+	cancel := c.applyDeadline()
+
+	c.logCmd("TOP %d %d", number, lines)
+
+	if err := c.Text.WriteLine("TOP %d %d", number, lines); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	msg, err := c.Text.ReadResponse()
+	c.logResp(msg, err)
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return newDotReader(c, cancel), nil
+}
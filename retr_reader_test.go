@@ -0,0 +1,30 @@
+package pop3
+
+import "testing"
+
+func TestUnstuffLine(t *testing.T) {
+	cases := []struct {
+		name           string
+		line           string
+		wantUnstuffed  string
+		wantTerminator bool
+	}{
+		{name: "terminator", line: ".", wantUnstuffed: "", wantTerminator: true},
+		{name: "stuffed single dot body line", line: "..", wantUnstuffed: ".", wantTerminator: false},
+		{name: "stuffed multi-dot body line", line: "...", wantUnstuffed: "..", wantTerminator: false},
+		{name: "stuffed leading-dot text", line: ".hello", wantUnstuffed: "hello", wantTerminator: false},
+		{name: "ordinary line", line: "hello", wantUnstuffed: "hello", wantTerminator: false},
+		{name: "empty line", line: "", wantUnstuffed: "", wantTerminator: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			unstuffed, terminator := unstuffLine(tc.line)
+
+			if unstuffed != tc.wantUnstuffed || terminator != tc.wantTerminator {
+				t.Errorf("unstuffLine(%q) = (%q, %v), want (%q, %v)",
+					tc.line, unstuffed, terminator, tc.wantUnstuffed, tc.wantTerminator)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package sasl
+
+import "testing"
+
+func TestPlainStart(t *testing.T) {
+	mech := Plain("zid", "user", "pass")
+
+	name, ir, err := mech.Start()
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if name != "PLAIN" {
+		t.Errorf("mechanism = %q, want PLAIN", name)
+	}
+
+	want := "zid\x00user\x00pass"
+	if string(ir) != want {
+		t.Errorf("initial response = %q, want %q", ir, want)
+	}
+}
+
+func TestPlainNextRejectsChallenge(t *testing.T) {
+	mech := Plain("", "user", "pass")
+
+	if _, _, err := mech.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if _, err := mech.Next([]byte("?")); err == nil {
+		t.Error("Next should reject any challenge for PLAIN, got nil error")
+	}
+}
+
+func TestLoginSteps(t *testing.T) {
+	mech := Login("user", "pass")
+
+	name, ir, err := mech.Start()
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if name != "LOGIN" {
+		t.Errorf("mechanism = %q, want LOGIN", name)
+	}
+
+	if ir != nil {
+		t.Errorf("initial response = %q, want nil", ir)
+	}
+
+	resp, err := mech.Next([]byte("Username:"))
+	if err != nil {
+		t.Fatalf("Next (username step): %v", err)
+	}
+
+	if string(resp) != "user" {
+		t.Errorf("username response = %q, want %q", resp, "user")
+	}
+
+	resp, err = mech.Next([]byte("Password:"))
+	if err != nil {
+		t.Fatalf("Next (password step): %v", err)
+	}
+
+	if string(resp) != "pass" {
+		t.Errorf("password response = %q, want %q", resp, "pass")
+	}
+
+	if _, err := mech.Next([]byte("?")); err == nil {
+		t.Error("Next should reject a third challenge for LOGIN, got nil error")
+	}
+}
+
+// TestCramMD5Next asserts against the worked example in RFC 2195 §3.
+func TestCramMD5Next(t *testing.T) {
+	mech := CramMD5("tim", "tanstaaftanstaaf")
+
+	name, ir, err := mech.Start()
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if name != "CRAM-MD5" {
+		t.Errorf("mechanism = %q, want CRAM-MD5", name)
+	}
+
+	if ir != nil {
+		t.Errorf("initial response = %q, want nil", ir)
+	}
+
+	challenge := []byte("<1896.697170952@postoffice.reston.mci.net>")
+
+	resp, err := mech.Next(challenge)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+
+	want := "tim b913a602c7eda7a495b4e6e7334d3890"
+	if string(resp) != want {
+		t.Errorf("response = %q, want %q", resp, want)
+	}
+}
+
+func TestXOAuth2Start(t *testing.T) {
+	mech := XOAuth2("user@example.com", "token123")
+
+	name, ir, err := mech.Start()
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if name != "XOAUTH2" {
+		t.Errorf("mechanism = %q, want XOAUTH2", name)
+	}
+
+	want := "user=user@example.com\x01auth=Bearer token123\x01\x01"
+	if string(ir) != want {
+		t.Errorf("initial response = %q, want %q", ir, want)
+	}
+}
@@ -0,0 +1,125 @@
+package pop3
+
+import (
+	"crypto/tls"
+)
+
+// StartTLS issues an STLS command to the server (RFC 2595) and, upon a
+// +OK response, upgrades the connection in place: c.conn is wrapped with
+// tls.Client and c.Text is replaced with a new Conn over the upgraded
+// connection. USER and PASS must only be sent after StartTLS returns
+// successfully.
+func (c *Client) StartTLS(config *tls.Config) error {
+	defer c.applyDeadline()()
+
+	if err := c.cmdSimple("STLS"); err != nil {
+		return err
+	}
+
+	conn := tls.Client(c.conn, config)
+
+	if err := conn.Handshake(); err != nil {
+		c.logger.Error("TLS handshake failed", "err", err)
+		return err
+	}
+
+	state := conn.ConnectionState()
+	c.logger.Info("TLS handshake complete", "version", state.Version, "cipherSuite", state.CipherSuite)
+
+	c.conn = conn
+	c.Text = NewConn(conn)
+
+	return nil
+}
+
+// DialStartTLS returns a new Client connected to a plaintext POP server
+// at addr, then immediately upgrades the connection to TLS with STLS.
+// Use this for servers that only listen on the plaintext port 110 and
+// advertise STLS rather than the implicit-TLS port 995.
+// The addr must include a port number.
+func DialStartTLS(addr string, config *tls.Config) (*Client, error) {
+	c, err := Dial(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.StartTLS(config); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// AuthStartTLS returns a new Client connected to a POP server at addr,
+// upgrades the connection to TLS with STLS, and authenticates with user
+// and pass.
+func AuthStartTLS(addr, user, pass string, config *tls.Config) (c *Client, err error) {
+	c, err = DialStartTLS(addr, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.User(user); err != nil {
+		return nil, err
+	}
+
+	if err = c.Pass(pass); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// ReceiveMailStartTLS connects to the plaintext server at addr, upgrades
+// to TLS with STLS, authenticates with user and pass, and calls
+// receiveFn for each mail.
+func ReceiveMailStartTLS(addr, user, pass string, config *tls.Config, receiveFn ReceiveMailFunc) error {
+	c, err := AuthStartTLS(addr, user, pass, config)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil && err != EOF {
+			c.Rset()
+		}
+
+		c.Quit()
+		c.Close()
+	}()
+
+	var mis []MessageInfo
+
+	if mis, err = c.listMessages(); err != nil {
+		return err
+	}
+
+	for _, mi := range mis {
+		var data string
+
+		data, err = c.Retr(mi.Number)
+
+		del, err := receiveFn(mi.Number, mi.Uid, data, err)
+
+		if c.IsClosed() {
+			c.logger.Info("reconnecting", "addr", addr)
+			c, err = AuthStartTLS(addr, user, pass, config)
+		}
+
+		if err != nil && err != EOF {
+			return err
+		}
+
+		if del {
+			if err = c.Dele(mi.Number); err != nil {
+				return err
+			}
+		}
+
+		if err == EOF {
+			break
+		}
+	}
+
+	return nil
+}